@@ -0,0 +1,162 @@
+package recapture
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// MatchStringInto matches a string against a regular expression, then binds
+// named subexpressions (as reported by (*regexp.Regexp).SubexpNames) into
+// dest, which must be one of:
+//
+//   - a pointer to a struct, in which case each named subexpression is
+//     bound to the exported field of the same name, or to the field tagged
+//     `recapture:"name"`. A field tagged `recapture:"-"` is never bound.
+//     A field tagged with the ",optional" suffix (e.g. `recapture:"year,optional"`)
+//     is left alone rather than returning an error when the pattern has no
+//     subexpression of that name.
+//   - a map[string]string, which receives the text of every named
+//     subexpression.
+//   - a map[string]Saver, in which each entry's Saver is invoked with the
+//     text of the identically-named subexpression; it is an error for a map
+//     key to name a subexpression the pattern doesn't have. Named
+//     subexpressions with no corresponding map entry are ignored.
+//   - a []interface{}, bound positionally exactly as the args of
+//     MatchString.
+//
+// As with MatchString, fields and map values are saved via the same rules
+// as save(), so int/uint/bool/float/string/Saver destinations all work.
+//
+// Returns an error (rather than panicking) if the regular expression didn't
+// match, if a struct field or map key names a subexpression the pattern
+// doesn't have (unless, for a struct field, tagged optional), or if a
+// destination failed to save its value. As with MatchString, it panics if
+// dest is a []interface{} of the wrong length for the pattern.
+func MatchStringInto(r *regexp.Regexp, s string, dest interface{}) error {
+	if args, ok := dest.([]interface{}); ok && r.NumSubexp() != len(args) {
+		panic(fmt.Sprintf("Expected %d arguments, got %d", r.NumSubexp(), len(args)))
+	}
+	submatches := r.FindStringSubmatch(s)
+	if submatches == nil {
+		return fmt.Errorf(
+			"regular expression did not match.\n\n"+
+				"regex: %#v\n"+
+				"input: %#v", r.String(), s)
+	}
+	names := r.SubexpNames()
+
+	switch dest := dest.(type) {
+	case map[string]string:
+		for i, name := range names {
+			if i == 0 || name == "" {
+				continue
+			}
+			dest[name] = submatches[i]
+		}
+		return nil
+	case map[string]Saver:
+		used := make(map[string]bool, len(dest))
+		for i, name := range names {
+			if i == 0 || name == "" {
+				continue
+			}
+			saver, ok := dest[name]
+			if !ok {
+				continue
+			}
+			used[name] = true
+			if err := saver.Save(submatches[i]); err != nil {
+				return fmt.Errorf("recapture: saving named group %q failed: %w", name, err)
+			}
+		}
+		for name := range dest {
+			if !used[name] {
+				return fmt.Errorf("recapture: no named capture group %q for map entry", name)
+			}
+		}
+		return nil
+	case []interface{}:
+		for i, arg := range dest {
+			if err := save(submatches[i+1], arg); err != nil {
+				return fmt.Errorf("recapture: submatch %d save failed: %w", i+1, err)
+			}
+		}
+		return nil
+	}
+
+	byName := make(map[string]int, len(names))
+	for i, name := range names {
+		if i == 0 || name == "" {
+			continue
+		}
+		byName[name] = i
+	}
+	return bindStruct(dest, submatches, byName)
+}
+
+// bindStruct binds submatches into the struct pointed to by dest, using
+// byName to look up the submatch index for a given subexpression name.
+//
+// A field without a `recapture` tag is matched against subexpression names
+// case-insensitively (regexp subexpression names are conventionally
+// lowerCamelCase, while matching exported fields must be upper case); a
+// tagged name is matched exactly. It is an error for an untagged field to
+// match more than one subexpression name case-insensitively, since regexp
+// permits two names differing only by case (e.g. "Year" and "year").
+func bindStruct(dest interface{}, submatches []string, byName map[string]int) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("recapture: MatchStringInto dest must be a struct pointer, map, or []interface{}, got %T", dest))
+	}
+	elem := v.Elem()
+	t := elem.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := field.Name
+		tagged := false
+		optional := false
+		if tag, ok := field.Tag.Lookup("recapture"); ok {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+				tagged = true
+			}
+			for _, opt := range parts[1:] {
+				if opt == "optional" {
+					optional = true
+				}
+			}
+		}
+		idx, ok := byName[name]
+		if !ok && !tagged {
+			var match string
+			for groupName, groupIdx := range byName {
+				if !strings.EqualFold(groupName, name) {
+					continue
+				}
+				if ok {
+					return fmt.Errorf("recapture: field %s.%s matches multiple capture groups %q and %q case-insensitively; add an explicit `recapture:\"name\"` tag", t.Name(), field.Name, match, groupName)
+				}
+				idx, ok, match = groupIdx, true, groupName
+			}
+		}
+		if !ok {
+			if optional {
+				continue
+			}
+			return fmt.Errorf("recapture: no named capture group %q for field %s.%s", name, t.Name(), field.Name)
+		}
+		if err := save(submatches[idx], elem.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("recapture: named group %q save failed: %w", name, err)
+		}
+	}
+	return nil
+}
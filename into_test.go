@@ -0,0 +1,154 @@
+package recapture
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_MatchStringIntoStruct(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<year>[0-9]{4})-(?P<month>[0-9]{2})-(?P<day>[0-9]{2})$`)
+	type date struct {
+		Year  int
+		Month int `recapture:"month"`
+		Day   int `recapture:"day"`
+	}
+	var d date
+	if err := MatchStringInto(r, "2013-09-26", &d); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if d != (date{2013, 9, 26}) {
+		t.Errorf("d = %+v", d)
+	}
+}
+
+func Test_MatchStringIntoStructMissingField(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<year>[0-9]{4})$`)
+	type date struct {
+		Year int
+	}
+	type dateWithExtra struct {
+		Year  int
+		Month int
+	}
+	var d dateWithExtra
+	err := MatchStringInto(r, "2013", &d)
+	if err == nil || !strings.Contains(err.Error(), `"Month"`) {
+		t.Errorf("err = %v", err)
+	}
+	var ok date
+	if err := MatchStringInto(r, "2013", &ok); err != nil {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func Test_MatchStringIntoStructOptionalField(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<year>[0-9]{4})$`)
+	type date struct {
+		Year  int
+		Month int `recapture:"month,optional"`
+	}
+	var d date
+	if err := MatchStringInto(r, "2013", &d); err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if d.Year != 2013 || d.Month != 0 {
+		t.Errorf("d = %+v", d)
+	}
+}
+
+func Test_MatchStringIntoStructSkippedField(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<year>[0-9]{4})$`)
+	type date struct {
+		Year     int
+		Internal int `recapture:"-"`
+	}
+	var d date
+	if err := MatchStringInto(r, "2013", &d); err != nil {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func Test_MatchStringIntoStructCaseInsensitiveAmbiguous(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<Year>[0-9]{4})-(?P<year>[0-9]{4})$`)
+	type date struct {
+		YEAR int
+	}
+	var d date
+	err := MatchStringInto(r, "2013-2014", &d)
+	if err == nil || !strings.Contains(err.Error(), "multiple capture groups") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func Test_MatchStringIntoMapString(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<year>[0-9]{4})-(?P<month>[0-9]{2})$`)
+	dest := make(map[string]string)
+	if err := MatchStringInto(r, "2013-09", dest); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if dest["year"] != "2013" || dest["month"] != "09" {
+		t.Errorf("dest = %+v", dest)
+	}
+}
+
+func Test_MatchStringIntoMapSaver(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<year>[0-9]{4})-(?P<month>[0-9]{2})$`)
+	var year int
+	dest := map[string]Saver{
+		"year": Hex(&year),
+	}
+	if err := MatchStringInto(r, "2013-09", dest); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if year != 0x2013 {
+		t.Errorf("year = %v", year)
+	}
+}
+
+func Test_MatchStringIntoMapSaverUnknownKey(t *testing.T) {
+	r := regexp.MustCompile(`^(?P<year>[0-9]{4})$`)
+	var year, month int
+	dest := map[string]Saver{
+		"year":  Hex(&year),
+		"month": Hex(&month),
+	}
+	err := MatchStringInto(r, "2013", dest)
+	if err == nil || !strings.Contains(err.Error(), `"month"`) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func Test_MatchStringIntoPositionalSliceCountMismatch(t *testing.T) {
+	var panicReason interface{}
+	r := regexp.MustCompile(`^([0-9]{4})-([0-9]{2})$`)
+	func() {
+		defer func() { panicReason = recover() }()
+		var year int
+		MatchStringInto(r, "2013-09", []interface{}{&year})
+	}()
+	if panicReason != "Expected 2 arguments, got 1" {
+		t.Errorf("panicReason = %v", panicReason)
+	}
+}
+
+func Test_MatchStringIntoPositionalSlice(t *testing.T) {
+	r := regexp.MustCompile(`^([0-9]{4})-([0-9]{2})$`)
+	var year, month int
+	if err := MatchStringInto(r, "2013-09", []interface{}{&year, &month}); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if year != 2013 || month != 9 {
+		t.Errorf("year, month = %v, %v", year, month)
+	}
+}
+
+func Test_MatchStringIntoNoMatch(t *testing.T) {
+	r := regexp.MustCompile(`^nada$`)
+	type dest struct{}
+	var d dest
+	err := MatchStringInto(r, "notnada", &d)
+	if err == nil || !strings.Contains(err.Error(), `"notnada"`) {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -22,8 +22,10 @@ package recapture
 
 import (
 	"bytes"
+	"encoding"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
@@ -34,6 +36,16 @@ type Saver interface {
 	Save(submatch string) error
 }
 
+// BytesSaver is an optional interface a Saver may also implement to save
+// directly from a byte slice, avoiding the string(submatch) allocation that
+// would otherwise be needed on the MatchBytes path. Savers that don't
+// implement it still work there; their Save method is simply called with a
+// converted string. MatchReader does not use it, as its submatches are
+// already strings.
+type BytesSaver interface {
+	SaveBytes(submatch []byte) error
+}
+
 type fmtarg struct {
 	format string
 	args   []interface{}
@@ -84,6 +96,14 @@ func CRadix(arg interface{}) integerSaver {
 	return integerSaver{0, arg}
 }
 
+// Base returns a Saver that will interpret integers in the given radix (as
+// accepted by strconv.ParseInt/ParseUint, so 2 through 36, or 0 to mean
+// CRadix's C-style prefix detection), saving the result to the location
+// pointed to by 'arg'.
+func Base(radix int, arg interface{}) integerSaver {
+	return integerSaver{radix, arg}
+}
+
 type runeSaver struct{ *rune }
 
 // Rune returns a Saver that saves a single rune to a location pointed to by
@@ -101,6 +121,18 @@ func (r runeSaver) Save(submatch string) (err error) {
 	return
 }
 
+func (r runeSaver) SaveBytes(submatch []byte) (err error) {
+	rn, size, err := bytes.NewReader(submatch).ReadRune()
+	if err != nil {
+		return err
+	}
+	*r.rune = rn
+	if size < len(submatch) {
+		return fmt.Errorf("did not consume last %d bytes of %v", len(submatch)-size, submatch)
+	}
+	return nil
+}
+
 type byteSaver struct{ *byte }
 
 // Byte returns a Saver that saves a single byte to a location pointed to by 'b'.
@@ -116,6 +148,14 @@ func (b byteSaver) Save(submatch string) (err error) {
 	return nil
 }
 
+func (b byteSaver) SaveBytes(submatch []byte) (err error) {
+	if len(submatch) != 1 {
+		return fmt.Errorf("expected 1 byte, got %d: %v", len(submatch), submatch)
+	}
+	*b.byte = submatch[0]
+	return nil
+}
+
 func (i integerSaver) Save(submatch string) (err error) {
 	switch arg := i.arg.(type) {
 	case *int:
@@ -157,9 +197,30 @@ func (i integerSaver) Save(submatch string) (err error) {
 	default:
 		panic(fmt.Sprintf("Unknown number type %T", arg))
 	}
+	if err != nil {
+		err = overflowError(submatch, i.arg, err)
+	}
 	return
 }
 
+// SaveBytes implements BytesSaver for Hex, Octal, CRadix, and Base. strconv
+// has no []byte-accepting integer parsers, so this still converts to a
+// string internally.
+func (i integerSaver) SaveBytes(submatch []byte) error {
+	return i.Save(string(submatch))
+}
+
+// overflowError rewrites a strconv.ErrRange failure into one that names the
+// destination type that the submatch overflowed, leaving other errors (e.g.
+// invalid syntax) untouched.
+func overflowError(submatch string, arg interface{}, err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) && numErr.Err == strconv.ErrRange {
+		return fmt.Errorf("value %q does not fit in %T: %w", submatch, arg, err)
+	}
+	return err
+}
+
 func save(submatch string, arg interface{}) (err error) {
 	switch arg := arg.(type) {
 	case Saver:
@@ -176,19 +237,59 @@ func save(submatch string, arg interface{}) (err error) {
 		*arg, err = strconv.ParseFloat(submatch, 64)
 	case *string:
 		*arg = submatch
+	case encoding.TextUnmarshaler:
+		err = arg.UnmarshalText([]byte(submatch))
 	default:
 		panic(fmt.Sprintf("Unknown argument type %T", arg))
 	}
 	return
 }
 
+// saveBytes is like save, but takes the submatch as a byte slice. If arg
+// implements BytesSaver, its SaveBytes method is used directly; otherwise it
+// falls back to save(string(submatch), arg).
+func saveBytes(submatch []byte, arg interface{}) (err error) {
+	if bs, ok := arg.(BytesSaver); ok {
+		return bs.SaveBytes(submatch)
+	}
+	return save(string(submatch), arg)
+}
+
+// noMatchError formats the error returned when a regular expression fails
+// to match its input.
+func noMatchError(r *regexp.Regexp, input string) error {
+	return fmt.Errorf(
+		"regular expression did not match.\n\n"+
+			"regex: %#v\n"+
+			"input: %#v", r.String(), input)
+}
+
+// saveFailedError formats the error returned when saving submatch
+// failedIndex into its destination argument failed with cause. submatches
+// includes the whole match at index 0, as returned by the various
+// FindSubmatch methods. The returned error wraps cause, so errors.Is/As
+// still see through to it (e.g. a *strconv.NumError from an overflow).
+func saveFailedError(r *regexp.Regexp, input string, submatches []string, failedIndex int, cause error) error {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf(
+		"submatch %d save failed.\n\n"+
+			"regex: %#v\n"+
+			"input: %#v\n",
+		failedIndex, r.String(), input))
+	for i, submatch := range submatches {
+		buffer.WriteString(fmt.Sprintf("\nsubmatch %d: %#v", i, submatch))
+	}
+	return fmt.Errorf("%s\ncause: %w", buffer.String(), cause)
+}
+
 // MatchString matches a string against a regular expression, capturing
 // numbered submatches into the corresponding positional arguments.
 //
-// 'args' may be string pointers, number pointers, boolean pointers, or
-// Savers. Note that byte/uint8 and rune/int32 are considered numbers,
-// not bytes/runes. (Use the Byte/Rune savers if byte/rune behavior is
-// desired.) complex64 and complex128 are not supported.
+// 'args' may be string pointers, number pointers, boolean pointers, Savers,
+// or pointers whose pointee implements encoding.TextUnmarshaler (such as
+// *net.IP or *big.Int). Note that byte/uint8 and rune/int32 are considered
+// numbers, not bytes/runes. (Use the Byte/Rune savers if byte/rune behavior
+// is desired.) complex64 and complex128 are not supported.
 //
 // Succeeds iff the regular expression matched AND argument parsing was
 // successful. Otherwise, returns an err with details of the failure,
@@ -202,24 +303,123 @@ func MatchString(r *regexp.Regexp, s string, args ...interface{}) (err error) {
 	}
 	submatches := r.FindStringSubmatch(s)
 	if submatches == nil {
-		return fmt.Errorf(
-			"regular expression did not match.\n\n"+
-				"regex: %#v\n"+
-				"input: %#v", r.String(), s)
+		return noMatchError(r, s)
 	}
 	for i, arg := range args {
-		err := save(submatches[i+1], arg)
-		if err != nil {
-			var buffer bytes.Buffer
-			buffer.WriteString(fmt.Sprintf(
-				"submatch %d save failed.\n\n"+
-					"regex: %#v\n"+
-					"input: %#v\n",
-				i+1, r.String(), s))
-			for i, submatch := range submatches {
-				buffer.WriteString(fmt.Sprintf("\nsubmatch %d: %#v", i, submatch))
+		if err := save(submatches[i+1], arg); err != nil {
+			return saveFailedError(r, s, submatches, i+1, err)
+		}
+	}
+	return nil
+}
+
+// MatchBytes is MatchString for a []byte input. It avoids converting each
+// submatch to a string before saving when the destination argument
+// implements BytesSaver.
+func MatchBytes(r *regexp.Regexp, b []byte, args ...interface{}) (err error) {
+	if r.NumSubexp() != len(args) {
+		panic(fmt.Sprintf("Expected %d arguments, got %d", r.NumSubexp(), len(args)))
+	}
+	submatches := r.FindSubmatch(b)
+	if submatches == nil {
+		return noMatchError(r, string(b))
+	}
+	for i, arg := range args {
+		if err := saveBytes(submatches[i+1], arg); err != nil {
+			strs := make([]string, len(submatches))
+			for j, submatch := range submatches {
+				strs[j] = string(submatch)
+			}
+			return saveFailedError(r, string(b), strs, i+1, err)
+		}
+	}
+	return nil
+}
+
+// MatchReader is MatchString for input read incrementally from an
+// io.RuneReader, using FindReaderSubmatchIndex so that huge inputs (e.g. a
+// file wrapped in a bufio.Reader) don't need to be read into memory ahead of
+// time. Note that the regexp package may read arbitrarily far past the end
+// of the eventual match before returning, and that on a failed match the
+// "input" included in the returned error is only however much of reader was
+// read before the engine gave up, not the full stream.
+func MatchReader(r *regexp.Regexp, reader io.RuneReader, args ...interface{}) (err error) {
+	if r.NumSubexp() != len(args) {
+		panic(fmt.Sprintf("Expected %d arguments, got %d", r.NumSubexp(), len(args)))
+	}
+	tee := &teeRuneReader{src: reader}
+	indices := r.FindReaderSubmatchIndex(tee)
+	consumed := tee.buf.String()
+	if indices == nil {
+		return noMatchError(r, consumed)
+	}
+	submatches := make([]string, len(indices)/2)
+	for i := range submatches {
+		lo, hi := indices[2*i], indices[2*i+1]
+		if lo < 0 {
+			continue
+		}
+		submatches[i] = consumed[lo:hi]
+	}
+	for i, arg := range args {
+		if err := save(submatches[i+1], arg); err != nil {
+			return saveFailedError(r, consumed, submatches, i+1, err)
+		}
+	}
+	return nil
+}
+
+// teeRuneReader wraps an io.RuneReader, recording every rune it successfully
+// reads. Once a caller is done consuming runes through it, buf holds their
+// UTF-8 encoding, which can be indexed using the byte offsets that
+// FindReaderSubmatchIndex returns.
+type teeRuneReader struct {
+	src io.RuneReader
+	buf bytes.Buffer
+}
+
+func (t *teeRuneReader) ReadRune() (r rune, size int, err error) {
+	r, size, err = t.src.ReadRune()
+	if err == nil {
+		t.buf.WriteRune(r)
+	}
+	return
+}
+
+// ForEachMatchString finds every non-overlapping match of a regular
+// expression in s (as FindAllStringSubmatchIndex would), re-binding the
+// same args to each successive match in turn and invoking fn after every
+// successful bind. It stops iterating and returns nil as soon as fn
+// returns io.EOF, or stops and returns any other error fn returns. Note
+// that all matches are found up front, so fn returning io.EOF early saves
+// further calls to fn and save(), but not the cost of finding the matches
+// that follow it in s.
+//
+// Panics if the number of arguments is not consistent with the regular
+// expression.
+func ForEachMatchString(r *regexp.Regexp, s string, fn func() error, args ...interface{}) error {
+	if r.NumSubexp() != len(args) {
+		panic(fmt.Sprintf("Expected %d arguments, got %d", r.NumSubexp(), len(args)))
+	}
+	for _, indices := range r.FindAllStringSubmatchIndex(s, -1) {
+		submatches := make([]string, len(indices)/2)
+		for i := range submatches {
+			lo, hi := indices[2*i], indices[2*i+1]
+			if lo < 0 {
+				continue
+			}
+			submatches[i] = s[lo:hi]
+		}
+		for i, arg := range args {
+			if err := save(submatches[i+1], arg); err != nil {
+				return saveFailedError(r, s, submatches, i+1, err)
+			}
+		}
+		if err := fn(); err != nil {
+			if err == io.EOF {
+				return nil
 			}
-			return errors.New(buffer.String())
+			return err
 		}
 	}
 	return nil
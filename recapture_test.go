@@ -1,7 +1,9 @@
 package recapture
 
 import (
+	"errors"
 	"io"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -62,6 +64,45 @@ func Test_SaveIntegerFailure(t *testing.T) {
 	}
 }
 
+func Test_SaveBaseSuccess(t *testing.T) {
+	var i int
+	err := save("101", Base(2, &i))
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if i != 5 {
+		t.Errorf("i = %v", i)
+	}
+}
+
+func Test_SaveIntegerOverflow(t *testing.T) {
+	var i int8
+	err := save("200", &i)
+	if err == nil || !strings.Contains(err.Error(), "does not fit in *int8") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func Test_MatchStringIntegerOverflow(t *testing.T) {
+	r := regexp.MustCompile(`^([0-9]+)$`)
+	var i int8
+	err := MatchString(r, "200", &i)
+	if err == nil || !strings.Contains(err.Error(), "does not fit in *int8") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func Test_SaveTextUnmarshaler(t *testing.T) {
+	var ip net.IP
+	err := save("127.0.0.1", &ip)
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if !ip.Equal(net.ParseIP("127.0.0.1")) {
+		t.Errorf("ip = %v", ip)
+	}
+}
+
 func Test_SaveFmtSuccess(t *testing.T) {
 	var i int
 	err := save("010", Fmt("%v", &i))
@@ -188,3 +229,116 @@ func Test_MatchSaveFailure(t *testing.T) {
 		t.Errorf("err: %v", err)
 	}
 }
+
+func Test_MatchBytes(t *testing.T) {
+	r := regexp.MustCompile(`^([0-9]{4})-([0-9]{2})-([0-9]{2})$`)
+	var m1, m2, m3 int
+	err := MatchBytes(r, []byte("2013-09-26"), &m1, &m2, &m3)
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if m1 != 2013 || m2 != 9 || m3 != 26 {
+		t.Errorf("m1, m2, m3 = %v, %v, %v", m1, m2, m3)
+	}
+}
+
+func Test_MatchBytesSaver(t *testing.T) {
+	r := regexp.MustCompile(`^(.)(.*)$`)
+	var b byte
+	var rest string
+	if err := MatchBytes(r, []byte("xyz"), Byte(&b), &rest); err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if b != 'x' || rest != "yz" {
+		t.Errorf("b, rest = %v, %v", b, rest)
+	}
+}
+
+func Test_MatchBytesHex(t *testing.T) {
+	r := regexp.MustCompile(`^(.*)$`)
+	var i int64
+	if err := MatchBytes(r, []byte("deadbeef"), Hex(&i)); err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if i != 0xdeadbeef {
+		t.Errorf("i = %v", i)
+	}
+}
+
+func Test_MatchBytesFailure(t *testing.T) {
+	r := regexp.MustCompile(`^nada$`)
+	input := "notnada"
+	err := MatchBytes(r, []byte(input))
+	if err == nil || !strings.Contains(err.Error(), strconv.Quote(input)) {
+		t.Errorf("err: %v", err)
+	}
+}
+
+func Test_MatchReader(t *testing.T) {
+	r := regexp.MustCompile(`^([0-9]{4})-([0-9]{2})-([0-9]{2})$`)
+	var m1, m2, m3 int
+	err := MatchReader(r, strings.NewReader("2013-09-26"), &m1, &m2, &m3)
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if m1 != 2013 || m2 != 9 || m3 != 26 {
+		t.Errorf("m1, m2, m3 = %v, %v, %v", m1, m2, m3)
+	}
+}
+
+// Unlike MatchString, MatchReader's diagnostic only reflects however much of
+// the input the regexp engine needed to read before giving up.
+func Test_MatchReaderFailure(t *testing.T) {
+	r := regexp.MustCompile(`^nada$`)
+	err := MatchReader(r, strings.NewReader("notnada"))
+	if err == nil || !strings.Contains(err.Error(), "did not match") {
+		t.Errorf("err: %v", err)
+	}
+}
+
+func Test_ForEachMatchString(t *testing.T) {
+	r := regexp.MustCompile(`([0-9]+)`)
+	var n int
+	var sum int
+	err := ForEachMatchString(r, "1 22 333", func() error {
+		sum += n
+		return nil
+	}, &n)
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if sum != 1+22+333 {
+		t.Errorf("sum = %v", sum)
+	}
+}
+
+func Test_ForEachMatchStringStopsOnEOF(t *testing.T) {
+	r := regexp.MustCompile(`([0-9]+)`)
+	var n int
+	var seen []int
+	err := ForEachMatchString(r, "1 22 333", func() error {
+		if n == 22 {
+			return io.EOF
+		}
+		seen = append(seen, n)
+		return nil
+	}, &n)
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if len(seen) != 1 || seen[0] != 1 {
+		t.Errorf("seen = %v", seen)
+	}
+}
+
+func Test_ForEachMatchStringPropagatesError(t *testing.T) {
+	r := regexp.MustCompile(`([0-9]+)`)
+	wantErr := errors.New("stop")
+	var n int
+	err := ForEachMatchString(r, "1 22 333", func() error {
+		return wantErr
+	}, &n)
+	if err != wantErr {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package recapturetest
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/scottlamb/recapture"
+)
+
+// wrapRe captures its entire (possibly multi-line) input as submatch 1, so
+// that ApplyType can route a single already-captured submatch back through
+// MatchString and thus the real saver dispatch.
+var wrapRe = regexp.MustCompile(`(?s)^(.*)$`)
+
+// ApplyType saves submatch through the recapture saver named by typ,
+// returning the fmt.Sprint representation of the value that was saved; for
+// "byte" and "rune" it is instead the character itself, and for "time" the
+// RFC-3339 date.
+//
+// Supported names: int, uint, int8, uint8, int16, uint16, int32, uint32,
+// int64, uint64, bool, float32, float64, string, byte, rune, hex, octal,
+// cradix, fmt (parsed with format "%d"), time (parsed/formatted with layout
+// "2006-01-02").
+func ApplyType(typ, submatch string) (string, error) {
+	switch typ {
+	case "int":
+		var v int
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "uint":
+		var v uint
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "int8":
+		var v int8
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "uint8":
+		var v uint8
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "int16":
+		var v int16
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "uint16":
+		var v uint16
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "int32":
+		var v int32
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "uint32":
+		var v uint32
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "int64":
+		var v int64
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "uint64":
+		var v uint64
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "bool":
+		var v bool
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "float32":
+		var v float32
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "float64":
+		var v float64
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return fmt.Sprint(v), err
+	case "string":
+		var v string
+		err := recapture.MatchString(wrapRe, submatch, &v)
+		return v, err
+	case "byte":
+		var v byte
+		err := recapture.MatchString(wrapRe, submatch, recapture.Byte(&v))
+		return string(rune(v)), err
+	case "rune":
+		var v rune
+		err := recapture.MatchString(wrapRe, submatch, recapture.Rune(&v))
+		return string(v), err
+	case "hex":
+		var v int64
+		err := recapture.MatchString(wrapRe, submatch, recapture.Hex(&v))
+		return fmt.Sprint(v), err
+	case "octal":
+		var v int64
+		err := recapture.MatchString(wrapRe, submatch, recapture.Octal(&v))
+		return fmt.Sprint(v), err
+	case "cradix":
+		var v int64
+		err := recapture.MatchString(wrapRe, submatch, recapture.CRadix(&v))
+		return fmt.Sprint(v), err
+	case "fmt":
+		var v int
+		err := recapture.MatchString(wrapRe, submatch, recapture.Fmt("%d", &v))
+		return fmt.Sprint(v), err
+	case "time":
+		var v time.Time
+		if err := recapture.MatchString(wrapRe, submatch, recapture.Time("2006-01-02", &v)); err != nil {
+			return "", err
+		}
+		return v.Format("2006-01-02"), nil
+	default:
+		return "", fmt.Errorf("recapturetest: unknown type %q", typ)
+	}
+}
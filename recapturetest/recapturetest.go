@@ -0,0 +1,263 @@
+// Package recapturetest provides a table-driven harness, inspired by RE2's
+// exhaustive test log format, for exercising the interaction between regexp
+// anchoring, empty/absent submatches, and every recapture saver.
+//
+// A testdata file is a sequence of stanzas:
+//
+//	strings
+//	"2013-09-26"
+//	"2013-13-99"
+//	"bogus"
+//
+//	regexps
+//	"^([0-9]{4})-([0-9]{2})-([0-9]{2})$" types=int,int,int
+//	0-10 0-4=2013 5-7=9 8-10=26
+//	0-10 0-4=2013 5-7=13 8-10=99
+//	-
+//
+// Lines starting with '#' and blank lines are ignored. The "strings" block
+// holds double-quoted input strings, one per line; only \" and \\ are
+// recognized escapes (so regexp metacharacters like \. need no doubling).
+// The "regexps" block holds one or more entries, each a quoted pattern in
+// the same style optionally followed by "types=t1,t2,..." naming the saver
+// type to apply to each subexpression (see ApplyType for the supported
+// names), followed by one result line per string in the "strings" block, in
+// order.
+//
+// A result line is "-" if the regexp does not match that string at all, or a
+// whitespace-separated list giving the whole match's "lo-hi" byte range
+// followed by one token per subexpression: "-" if that group did not
+// participate in the match, or "lo-hi=value" if it did, where value is the
+// string ApplyType is expected to produce from that submatch. A bare "lo-hi"
+// checks only the group's range, without running ApplyType on it.
+package recapturetest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type regexEntry struct {
+	pattern string
+	types   []string
+	results []resultLine
+}
+
+// resultLine is nil if the regexp did not match; otherwise it holds the
+// whole match range followed by one entry per subexpression.
+type resultLine []groupResult
+
+type groupResult struct {
+	matched  bool
+	lo, hi   int
+	expected string // only meaningful if matched and a "=value" was given
+	hasValue bool
+}
+
+type stanza struct {
+	strings []string
+	regexes []regexEntry
+}
+
+// Run parses the testdata file at path and runs one subtest per
+// (regexp, string) pair found in it, reporting failures through t.
+func Run(t *testing.T, path string) {
+	t.Helper()
+	st, err := parseFile(path)
+	if err != nil {
+		t.Fatalf("recapturetest: %v", err)
+	}
+	for _, re := range st.regexes {
+		r := regexp.MustCompile(re.pattern)
+		if got, want := r.NumSubexp(), len(re.types); got != want && want != 0 {
+			t.Fatalf("recapturetest: regex %q has %d subexpressions but types= names %d", re.pattern, got, want)
+		}
+		for i, s := range st.strings {
+			want := re.results[i]
+			t.Run(re.pattern+"/"+strconv.Quote(s), func(t *testing.T) {
+				checkMatch(t, r, re.types, s, want)
+			})
+		}
+	}
+}
+
+func checkMatch(t *testing.T, r *regexp.Regexp, types []string, s string, want resultLine) {
+	t.Helper()
+	indices := r.FindStringSubmatchIndex(s)
+	if want == nil {
+		if indices != nil {
+			t.Errorf("expected no match, got %v", indices)
+		}
+		return
+	}
+	if indices == nil {
+		t.Fatalf("expected match %v, got no match", want)
+	}
+	whole := want[0]
+	if indices[0] != whole.lo || indices[1] != whole.hi {
+		t.Errorf("whole match = %d-%d, want %d-%d", indices[0], indices[1], whole.lo, whole.hi)
+	}
+	for i, g := range want[1:] {
+		lo, hi := indices[2*(i+1)], indices[2*(i+1)+1]
+		if !g.matched {
+			if lo >= 0 {
+				t.Errorf("group %d: expected no participation, got %d-%d", i+1, lo, hi)
+			}
+			continue
+		}
+		if lo != g.lo || hi != g.hi {
+			t.Errorf("group %d = %d-%d, want %d-%d", i+1, lo, hi, g.lo, g.hi)
+			continue
+		}
+		if !g.hasValue {
+			continue
+		}
+		got, err := ApplyType(types[i], s[lo:hi])
+		if err != nil {
+			t.Errorf("group %d: ApplyType(%q, %q) failed: %v", i+1, types[i], s[lo:hi], err)
+			continue
+		}
+		if got != g.expected {
+			t.Errorf("group %d: ApplyType(%q, %q) = %q, want %q", i+1, types[i], s[lo:hi], got, g.expected)
+		}
+	}
+}
+
+var quotedLineRE = regexp.MustCompile(`^("(?:[^"\\]|\\.)*")(?:\s+types=(\S+))?$`)
+
+// unquote strips the surrounding double quotes from a quoted testdata
+// literal, unescaping only \" and \\. Unlike strconv.Unquote, any other
+// backslash sequence (e.g. the \. in a regexp pattern) passes through
+// unchanged, so patterns need no extra escaping beyond quoting.
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("not a quoted string: %s", s)
+	}
+	inner := s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) && (inner[i+1] == '"' || inner[i+1] == '\\') {
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String(), nil
+}
+
+func parseFile(path string) (stanza, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return stanza{}, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return stanza{}, err
+	}
+
+	if len(lines) == 0 || lines[0] != "strings" {
+		return stanza{}, fmt.Errorf("%s: expected \"strings\" as first stanza", path)
+	}
+	lines = lines[1:]
+
+	var st stanza
+	for len(lines) > 0 && lines[0] != "regexps" {
+		s, err := unquote(lines[0])
+		if err != nil {
+			return stanza{}, fmt.Errorf("%s: bad quoted string %q: %w", path, lines[0], err)
+		}
+		st.strings = append(st.strings, s)
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return stanza{}, fmt.Errorf("%s: expected \"regexps\" stanza", path)
+	}
+	lines = lines[1:]
+
+	for len(lines) > 0 {
+		m := quotedLineRE.FindStringSubmatch(lines[0])
+		if m == nil {
+			return stanza{}, fmt.Errorf("%s: bad regexp line %q", path, lines[0])
+		}
+		pattern, err := unquote(m[1])
+		if err != nil {
+			return stanza{}, fmt.Errorf("%s: bad quoted pattern %q: %w", path, m[1], err)
+		}
+		var types []string
+		if m[2] != "" {
+			types = strings.Split(m[2], ",")
+		}
+		lines = lines[1:]
+
+		entry := regexEntry{pattern: pattern, types: types}
+		for range st.strings {
+			if len(lines) == 0 {
+				return stanza{}, fmt.Errorf("%s: missing result line for regexp %q", path, pattern)
+			}
+			rl, err := parseResultLine(lines[0])
+			if err != nil {
+				return stanza{}, fmt.Errorf("%s: %w", path, err)
+			}
+			entry.results = append(entry.results, rl)
+			lines = lines[1:]
+		}
+		st.regexes = append(st.regexes, entry)
+	}
+	return st, nil
+}
+
+func parseResultLine(line string) (resultLine, error) {
+	if line == "-" {
+		return nil, nil
+	}
+	fields := strings.Fields(line)
+	rl := make(resultLine, len(fields))
+	for i, field := range fields {
+		g, err := parseGroupResult(field)
+		if err != nil {
+			return nil, fmt.Errorf("bad result field %q: %w", field, err)
+		}
+		rl[i] = g
+	}
+	return rl, nil
+}
+
+func parseGroupResult(field string) (groupResult, error) {
+	if field == "-" {
+		return groupResult{matched: false}, nil
+	}
+	rng := field
+	var value string
+	hasValue := false
+	if eq := strings.IndexByte(field, '='); eq >= 0 {
+		rng, value = field[:eq], field[eq+1:]
+		hasValue = true
+	}
+	dash := strings.IndexByte(rng, '-')
+	if dash < 0 {
+		return groupResult{}, fmt.Errorf("missing '-' in range %q", rng)
+	}
+	lo, err := strconv.Atoi(rng[:dash])
+	if err != nil {
+		return groupResult{}, fmt.Errorf("bad low offset in %q: %w", rng, err)
+	}
+	hi, err := strconv.Atoi(rng[dash+1:])
+	if err != nil {
+		return groupResult{}, fmt.Errorf("bad high offset in %q: %w", rng, err)
+	}
+	return groupResult{matched: true, lo: lo, hi: hi, expected: value, hasValue: hasValue}, nil
+}
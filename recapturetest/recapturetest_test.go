@@ -0,0 +1,7 @@
+package recapturetest
+
+import "testing"
+
+func Test_Basic(t *testing.T) {
+	Run(t, "testdata/basic.txt")
+}
@@ -0,0 +1,62 @@
+package recapture
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+type repeatedSaver struct {
+	sep *regexp.Regexp
+	arg interface{}
+}
+
+// Repeated returns a Saver that splits a submatch on sep, saving each piece
+// through the normal save() dispatch into successive elements of the slice
+// pointed to by 'arg' (e.g. *[]int, *[]string, or a slice of a type
+// implementing Saver or encoding.TextUnmarshaler). This is useful when a
+// single capture group matches a delimited list, such as "1,2,3,4" captured
+// by "([0-9,]+)".
+func Repeated(sep *regexp.Regexp, arg interface{}) Saver {
+	return repeatedSaver{sep, arg}
+}
+
+func (r repeatedSaver) Save(submatch string) error {
+	return saveElements(r.sep.Split(submatch, -1), r.arg)
+}
+
+type splitSaver struct {
+	sep rune
+	arg interface{}
+}
+
+// Split is Repeated for the common case of a single-rune separator.
+func Split(sep rune, arg interface{}) Saver {
+	return splitSaver{sep, arg}
+}
+
+func (s splitSaver) Save(submatch string) error {
+	return saveElements(strings.Split(submatch, string(s.sep)), s.arg)
+}
+
+// saveElements saves each of pieces, in order, into the corresponding
+// element of a new slice, which then replaces the slice pointed to by 'arg'.
+// If any piece fails to save, the slice pointed to by 'arg' is left unchanged.
+func saveElements(pieces []string, arg interface{}) error {
+	v := reflect.ValueOf(arg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		panic(fmt.Sprintf("recapture: Repeated/Split dest must be a slice pointer, got %T", arg))
+	}
+	sliceType := v.Elem().Type()
+	result := reflect.MakeSlice(sliceType, len(pieces), len(pieces))
+	for i, piece := range pieces {
+		elem := reflect.New(sliceType.Elem())
+		if err := save(piece, elem.Interface()); err != nil {
+			return fmt.Errorf("recapture: element %d save failed: %w", i, err)
+		}
+		result.Index(i).Set(elem.Elem())
+	}
+	v.Elem().Set(result)
+	return nil
+}
@@ -0,0 +1,64 @@
+package recapture
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_RepeatedInts(t *testing.T) {
+	r := regexp.MustCompile(`^\[([0-9,]+)\]$`)
+	var nums []int
+	err := MatchString(r, "[1,2,3,4]", Repeated(regexp.MustCompile(`,`), &nums))
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	want := []int{1, 2, 3, 4}
+	if len(nums) != len(want) {
+		t.Fatalf("nums = %v", nums)
+	}
+	for i := range want {
+		if nums[i] != want[i] {
+			t.Errorf("nums[%d] = %v, want %v", i, nums[i], want[i])
+		}
+	}
+}
+
+func Test_RepeatedStrings(t *testing.T) {
+	r := regexp.MustCompile(`^(.*)$`)
+	var words []string
+	err := MatchString(r, "foo;bar;baz", Repeated(regexp.MustCompile(`;`), &words))
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if strings.Join(words, ",") != "foo,bar,baz" {
+		t.Errorf("words = %v", words)
+	}
+}
+
+func Test_RepeatedElementError(t *testing.T) {
+	var nums []int
+	err := Repeated(regexp.MustCompile(`,`), &nums).Save("1,asdf,3")
+	if err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func Test_SplitInts(t *testing.T) {
+	var nums []int
+	err := Split(',', &nums).Save("1,2,3")
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if len(nums) != 3 || nums[0] != 1 || nums[1] != 2 || nums[2] != 3 {
+		t.Errorf("nums = %v", nums)
+	}
+}
+
+func Test_SplitElementError(t *testing.T) {
+	var nums []int
+	err := Split(',', &nums).Save("1,2,asdf")
+	if err == nil || !strings.Contains(err.Error(), "element 2") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package recapture
+
+import "time"
+
+type timeSaver struct {
+	layout string
+	dest   *time.Time
+}
+
+// Time returns a Saver that parses a submatch with the given time.Parse
+// layout, saving the result to the location pointed to by 'dest'.
+func Time(layout string, dest *time.Time) Saver {
+	return timeSaver{layout, dest}
+}
+
+func (t timeSaver) Save(submatch string) error {
+	parsed, err := time.Parse(t.layout, submatch)
+	if err != nil {
+		return err
+	}
+	*t.dest = parsed
+	return nil
+}
+
+type durationSaver struct{ dest *time.Duration }
+
+// Duration returns a Saver that parses a submatch with time.ParseDuration,
+// saving the result to the location pointed to by 'dest'.
+func Duration(dest *time.Duration) Saver {
+	return durationSaver{dest}
+}
+
+func (d durationSaver) Save(submatch string) error {
+	parsed, err := time.ParseDuration(submatch)
+	if err != nil {
+		return err
+	}
+	*d.dest = parsed
+	return nil
+}
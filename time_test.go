@@ -0,0 +1,44 @@
+package recapture
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_SaveTimeSuccess(t *testing.T) {
+	var tm time.Time
+	err := save("2013-09-26", Time("2006-01-02", &tm))
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if want := time.Date(2013, time.September, 26, 0, 0, 0, 0, time.UTC); !tm.Equal(want) {
+		t.Errorf("tm = %v", tm)
+	}
+}
+
+func Test_SaveTimeFailure(t *testing.T) {
+	var tm time.Time
+	err := save("not-a-date", Time("2006-01-02", &tm))
+	if err == nil {
+		t.Error("no error")
+	}
+}
+
+func Test_SaveDurationSuccess(t *testing.T) {
+	var d time.Duration
+	err := save("1h30m", Duration(&d))
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if d != 90*time.Minute {
+		t.Errorf("d = %v", d)
+	}
+}
+
+func Test_SaveDurationFailure(t *testing.T) {
+	var d time.Duration
+	err := save("not-a-duration", Duration(&d))
+	if err == nil {
+		t.Error("no error")
+	}
+}
@@ -0,0 +1,23 @@
+package recapture
+
+import "net/url"
+
+type urlSaver struct{ dest *url.URL }
+
+// URL returns a Saver that parses a submatch with url.Parse, saving the
+// result to the location pointed to by 'dest'. It exists because url.URL
+// implements encoding.BinaryUnmarshaler but not encoding.TextUnmarshaler, so
+// it can't be saved via the generic TextUnmarshaler dispatch that save()
+// otherwise uses for types like *net.IP.
+func URL(dest *url.URL) Saver {
+	return urlSaver{dest}
+}
+
+func (u urlSaver) Save(submatch string) error {
+	parsed, err := url.Parse(submatch)
+	if err != nil {
+		return err
+	}
+	*u.dest = *parsed
+	return nil
+}
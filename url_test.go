@@ -0,0 +1,25 @@
+package recapture
+
+import (
+	"net/url"
+	"testing"
+)
+
+func Test_SaveURLSuccess(t *testing.T) {
+	var u url.URL
+	err := save("https://example.com/path?q=1", URL(&u))
+	if err != nil {
+		t.Errorf("err = %v", err)
+	}
+	if u.Host != "example.com" || u.Path != "/path" || u.RawQuery != "q=1" {
+		t.Errorf("u = %+v", u)
+	}
+}
+
+func Test_SaveURLFailure(t *testing.T) {
+	var u url.URL
+	err := save("://bad", URL(&u))
+	if err == nil {
+		t.Error("no error")
+	}
+}